@@ -0,0 +1,144 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeSLO is the Schema for the nodeslos API, carrying the node-level SLO
+// strategies that used to be distributed as a flat ConfigMap.
+type NodeSLO struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NodeSLOSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeSLOList contains a list of NodeSLO.
+type NodeSLOList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodeSLO `json:"items"`
+}
+
+// NodeSLOSpec groups the per-QoS-class CPU-burst strategies that the burst
+// reconciler merges with pod annotations. Fields are pointers so that an
+// unset field falls back to the existing ConfigMap-derived default.
+type NodeSLOSpec struct {
+	CPUBurstStrategy *CPUBurstStrategy `json:"cpuBurstStrategy,omitempty"`
+}
+
+// QoSClass mirrors the pod QoS classes that a CPUBurstStrategy can be scoped to.
+type QoSClass string
+
+const (
+	QoSLSR QoSClass = "LSR"
+	QoSLS  QoSClass = "LS"
+	QoSBE  QoSClass = "BE"
+)
+
+// CPUBurstStrategy is the node-level default for CFS quota burst, optionally
+// overridden per QoS class.
+type CPUBurstStrategy struct {
+	// CFSQuotaBurstPercent caps how far a container's cfs_quota_us may grow
+	// above its base quota, expressed as a percentage of the base (e.g. 200
+	// means the quota may burst up to 2x).
+	CFSQuotaBurstPercent *int64 `json:"cfsQuotaBurstPercent,omitempty"`
+	// CPUBurstPeriod is a burst period in seconds. Neither the CPUBurst
+	// strategy nor the runtime hook reads it, so it has no effect on how long
+	// a burst quota is kept.
+	CPUBurstPeriod *int64 `json:"cpuBurstPeriod,omitempty"`
+	// SharePoolThresholdPercent is a node-level aggregate CPU usage percent.
+	// Neither the CPUBurst strategy nor the runtime hook reads it: bursting is
+	// not suspended when node CPU usage is high.
+	SharePoolThresholdPercent *int64 `json:"sharePoolThresholdPercent,omitempty"`
+	// QoSStrategies overrides the node default for individual QoS classes.
+	QoSStrategies map[QoSClass]*CPUBurstStrategy `json:"qosStrategies,omitempty"`
+}
+
+// DeepCopy creates a deep copy of the NodeSLO.
+func (in *NodeSLO) DeepCopy() *NodeSLO {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSLO)
+	out.TypeMeta = in.TypeMeta
+	out.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	out.Spec = *in.Spec.DeepCopy()
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *NodeSLO) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// DeepCopy creates a deep copy of the NodeSLOList.
+func (in *NodeSLOList) DeepCopy() *NodeSLOList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSLOList)
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		out.Items = make([]NodeSLO, len(in.Items))
+		for i := range in.Items {
+			out.Items[i] = *in.Items[i].DeepCopy()
+		}
+	}
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *NodeSLOList) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// DeepCopy creates a deep copy of the NodeSLOSpec.
+func (in *NodeSLOSpec) DeepCopy() *NodeSLOSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSLOSpec)
+	if in.CPUBurstStrategy != nil {
+		out.CPUBurstStrategy = in.CPUBurstStrategy.DeepCopy()
+	}
+	return out
+}
+
+// DeepCopy creates a deep copy of the CPUBurstStrategy.
+func (in *CPUBurstStrategy) DeepCopy() *CPUBurstStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(CPUBurstStrategy)
+	*out = *in
+	if in.QoSStrategies != nil {
+		out.QoSStrategies = make(map[QoSClass]*CPUBurstStrategy, len(in.QoSStrategies))
+		for qos, strategy := range in.QoSStrategies {
+			out.QoSStrategies[qos] = strategy.DeepCopy()
+		}
+	}
+	return out
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package versioned is the typed clientset for the slo.koordinator.sh API
+// group, following the same shape client-gen would produce.
+package versioned
+
+import (
+	"fmt"
+
+	discovery "k8s.io/client-go/discovery"
+	rest "k8s.io/client-go/rest"
+	flowcontrol "k8s.io/client-go/util/flowcontrol"
+
+	slov1alpha1 "github.com/clay-wangzhi/cfs-quota-burst/pkg/client/clientset/versioned/typed/slo/v1alpha1"
+)
+
+// Interface is the set of typed clients this clientset exposes. koordlet
+// depends on it rather than *Clientset so tests can supply a fake.
+type Interface interface {
+	Discovery() discovery.DiscoveryInterface
+	SloV1alpha1() slov1alpha1.SloV1alpha1Interface
+}
+
+// Clientset contains the clients for each group-version this module knows
+// about.
+type Clientset struct {
+	*discovery.DiscoveryClient
+	sloV1alpha1 *slov1alpha1.SloV1alpha1Client
+}
+
+// SloV1alpha1 retrieves the SloV1alpha1Client.
+func (c *Clientset) SloV1alpha1() slov1alpha1.SloV1alpha1Interface {
+	return c.sloV1alpha1
+}
+
+// Discovery retrieves the DiscoveryClient.
+func (c *Clientset) Discovery() discovery.DiscoveryInterface {
+	if c == nil {
+		return nil
+	}
+	return c.DiscoveryClient
+}
+
+// NewForConfig creates a new Clientset for the given config.
+func NewForConfig(c *rest.Config) (*Clientset, error) {
+	configShallowCopy := *c
+	if configShallowCopy.RateLimiter == nil && configShallowCopy.QPS > 0 {
+		configShallowCopy.RateLimiter = flowcontrol.NewTokenBucketRateLimiter(configShallowCopy.QPS, configShallowCopy.Burst)
+	}
+
+	var cs Clientset
+	var err error
+	cs.sloV1alpha1, err = slov1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build discovery client, err: %w", err)
+	}
+	return &cs, nil
+}
+
+// NewForConfigOrDie creates a new Clientset for the given config and panics
+// if there is an error in the config.
+func NewForConfigOrDie(c *rest.Config) *Clientset {
+	cs, err := NewForConfig(c)
+	if err != nil {
+		panic(err)
+	}
+	return cs
+}
+
+// New creates a new Clientset for the given RESTClient.
+func New(c rest.Interface) *Clientset {
+	var cs Clientset
+	cs.sloV1alpha1 = slov1alpha1.New(c)
+	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
+	return &cs
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+
+	slov1alpha1 "github.com/clay-wangzhi/cfs-quota-burst/pkg/apis/slo/v1alpha1"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/client/clientset/versioned/scheme"
+)
+
+// NodeSLOsGetter has a method to return a NodeSLOInterface.
+type NodeSLOsGetter interface {
+	NodeSLOs() NodeSLOInterface
+}
+
+// NodeSLOInterface has methods to work with NodeSLO resources. NodeSLO is
+// cluster-scoped, so there is no namespace parameter.
+type NodeSLOInterface interface {
+	Create(ctx context.Context, nodeSLO *slov1alpha1.NodeSLO, opts metav1.CreateOptions) (*slov1alpha1.NodeSLO, error)
+	Update(ctx context.Context, nodeSLO *slov1alpha1.NodeSLO, opts metav1.UpdateOptions) (*slov1alpha1.NodeSLO, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*slov1alpha1.NodeSLO, error)
+	List(opts metav1.ListOptions) (*slov1alpha1.NodeSLOList, error)
+	Watch(opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*slov1alpha1.NodeSLO, error)
+}
+
+// nodeSLOs implements NodeSLOInterface.
+type nodeSLOs struct {
+	client rest.Interface
+}
+
+// newNodeSLOs returns a NodeSLOs.
+func newNodeSLOs(c *SloV1alpha1Client) *nodeSLOs {
+	return &nodeSLOs{client: c.RESTClient()}
+}
+
+func (c *nodeSLOs) Get(ctx context.Context, name string, opts metav1.GetOptions) (result *slov1alpha1.NodeSLO, err error) {
+	result = &slov1alpha1.NodeSLO{}
+	err = c.client.Get().
+		Resource("nodeslos").
+		Name(name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of NodeSLOs
+// that match those selectors. It does not take a context, matching the
+// ListFunc signature cache.ListWatch expects.
+func (c *nodeSLOs) List(opts metav1.ListOptions) (result *slov1alpha1.NodeSLOList, err error) {
+	result = &slov1alpha1.NodeSLOList{}
+	err = c.client.Get().
+		Resource("nodeslos").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(context.Background()).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested NodeSLOs,
+// matching the WatchFunc signature cache.ListWatch expects.
+func (c *nodeSLOs) Watch(opts metav1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Resource("nodeslos").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch(context.Background())
+}
+
+func (c *nodeSLOs) Create(ctx context.Context, nodeSLO *slov1alpha1.NodeSLO, opts metav1.CreateOptions) (result *slov1alpha1.NodeSLO, err error) {
+	result = &slov1alpha1.NodeSLO{}
+	err = c.client.Post().
+		Resource("nodeslos").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(nodeSLO).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+func (c *nodeSLOs) Update(ctx context.Context, nodeSLO *slov1alpha1.NodeSLO, opts metav1.UpdateOptions) (result *slov1alpha1.NodeSLO, err error) {
+	result = &slov1alpha1.NodeSLO{}
+	err = c.client.Put().
+		Resource("nodeslos").
+		Name(nodeSLO.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(nodeSLO).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+func (c *nodeSLOs) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("nodeslos").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+func (c *nodeSLOs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *slov1alpha1.NodeSLO, err error) {
+	result = &slov1alpha1.NodeSLO{}
+	err = c.client.Patch(pt).
+		Resource("nodeslos").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
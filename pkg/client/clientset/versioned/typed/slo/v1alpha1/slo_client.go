@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/client-go/rest"
+
+	slov1alpha1 "github.com/clay-wangzhi/cfs-quota-burst/pkg/apis/slo/v1alpha1"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/client/clientset/versioned/scheme"
+)
+
+// SloV1alpha1Interface has a method to return a NodeSLOsGetter, enabling
+// callers to build NodeSLO clients without depending on the concrete
+// SloV1alpha1Client type.
+type SloV1alpha1Interface interface {
+	RESTClient() rest.Interface
+	NodeSLOsGetter
+}
+
+// SloV1alpha1Client is used to interact with features provided by the
+// slo.koordinator.sh group.
+type SloV1alpha1Client struct {
+	restClient rest.Interface
+}
+
+func (c *SloV1alpha1Client) NodeSLOs() NodeSLOInterface {
+	return newNodeSLOs(c)
+}
+
+// NewForConfig creates a new SloV1alpha1Client for the given config.
+func NewForConfig(c *rest.Config) (*SloV1alpha1Client, error) {
+	config := *c
+	if err := setConfigDefaults(&config); err != nil {
+		return nil, err
+	}
+	client, err := rest.RESTClientFor(&config)
+	if err != nil {
+		return nil, err
+	}
+	return &SloV1alpha1Client{restClient: client}, nil
+}
+
+// NewForConfigOrDie creates a new SloV1alpha1Client for the given config and
+// panics if there is an error in the config.
+func NewForConfigOrDie(c *rest.Config) *SloV1alpha1Client {
+	client, err := NewForConfig(c)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// New creates a new SloV1alpha1Client for the given RESTClient.
+func New(c rest.Interface) *SloV1alpha1Client {
+	return &SloV1alpha1Client{restClient: c}
+}
+
+func setConfigDefaults(config *rest.Config) error {
+	gv := slov1alpha1.SchemeGroupVersion
+	config.GroupVersion = &gv
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = scheme.Codecs.WithoutConversion()
+
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+
+	return nil
+}
+
+// RESTClient returns a RESTClient that is used to communicate with API
+// server by this client implementation.
+func (c *SloV1alpha1Client) RESTClient() rest.Interface {
+	if c == nil {
+		return nil
+	}
+	return c.restClient
+}
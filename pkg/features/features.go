@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/component-base/featuregate"
+)
+
+const (
+	// CPUBurst enables the CFS quota burst QoS strategy.
+	CPUBurst featuregate.Feature = "CPUBurst"
+
+	// CPUSuppress is the gate for a CPU suppress QoS strategy; no strategy in
+	// qosmanager.PluginRegistry checks it.
+	CPUSuppress featuregate.Feature = "CPUSuppress"
+
+	// MemoryEvict is the gate for a memory evict QoS strategy; no strategy in
+	// qosmanager.PluginRegistry checks it.
+	MemoryEvict featuregate.Feature = "MemoryEvict"
+
+	// CPUEvict is the gate for a CPU evict QoS strategy; no strategy in
+	// qosmanager.PluginRegistry checks it.
+	CPUEvict featuregate.Feature = "CPUEvict"
+)
+
+var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
+	CPUBurst:    {Default: true, PreRelease: featuregate.Beta},
+	CPUSuppress: {Default: false, PreRelease: featuregate.Alpha},
+	MemoryEvict: {Default: false, PreRelease: featuregate.Alpha},
+	CPUEvict:    {Default: false, PreRelease: featuregate.Alpha},
+}
+
+// DefaultMutableFeatureGate is a mutable, shared global FeatureGate.
+var DefaultMutableFeatureGate = featuregate.NewFeatureGate()
+
+// DefaultFeatureGate is the readonly interface to DefaultMutableFeatureGate.
+var DefaultFeatureGate featuregate.FeatureGate = DefaultMutableFeatureGate
+
+func init() {
+	runtime.Must(DefaultMutableFeatureGate.Add(defaultFeatureGates))
+}
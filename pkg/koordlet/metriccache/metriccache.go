@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metriccache is the shared store collectors write into and
+// reconcilers read from, so the two never need a direct reference to each
+// other.
+package metriccache
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// KVStorage is the generic get/set half of MetricCache, used by collectors
+// that only need to publish a single latest value keyed by name (e.g. node
+// CPU info).
+type KVStorage interface {
+	Set(key string, value interface{})
+	Get(key string) (interface{}, bool)
+}
+
+// NodeCPUInfoKey is the KVStorage key the nodeinfo collector publishes
+// NodeCPUInfo under.
+const NodeCPUInfoKey = "NodeCPUInfo"
+
+// ProcessorInfo describes one logical CPU as reported by the node.
+type ProcessorInfo struct {
+	CPUID    int32
+	CoreID   int32
+	SocketID int32
+}
+
+// CPUTotalInfo summarizes the processors above across the whole node.
+type CPUTotalInfo struct {
+	NumberCPUs int32
+}
+
+// NodeCPUInfo is the value the nodeinfo collector stores under
+// NodeCPUInfoKey.
+type NodeCPUInfo struct {
+	ProcessorInfos []ProcessorInfo
+	TotalInfo      CPUTotalInfo
+}
+
+// ContainerThrottledMetric is one window's worth of cpu.stat deltas for a
+// container, as observed by containerThrottledCollector and consumed by the
+// CPUBurst reconciler to drive the adaptive quota multiplier.
+type ContainerThrottledMetric struct {
+	NRPeriodsDelta     uint64
+	NRThrottledDelta   uint64
+	ThrottledTimeDelta uint64
+	Timestamp          time.Time
+}
+
+// MetricCache is the store every collector writes into and every
+// QoSStrategy/hook reads from. It embeds KVStorage for collectors that only
+// need a single latest value, plus typed methods for per-container throttling
+// metrics. Only the most recently inserted metric per container is kept; no
+// history is stored.
+type MetricCache interface {
+	KVStorage
+
+	InsertContainerThrottledMetric(containerID string, metric *ContainerThrottledMetric) error
+	GetLatestContainerThrottledMetric(containerID string) (*ContainerThrottledMetric, error)
+}
+
+type metricCache struct {
+	mutex sync.RWMutex
+	kv    map[string]interface{}
+
+	throttledMutex sync.RWMutex
+	throttled      map[string]*ContainerThrottledMetric
+}
+
+// NewMetricCache returns the in-memory MetricCache koordlet wires every
+// collector and QoSStrategy to.
+func NewMetricCache() MetricCache {
+	return &metricCache{
+		kv:        map[string]interface{}{},
+		throttled: map[string]*ContainerThrottledMetric{},
+	}
+}
+
+func (m *metricCache) Set(key string, value interface{}) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.kv[key] = value
+}
+
+func (m *metricCache) Get(key string) (interface{}, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	value, ok := m.kv[key]
+	return value, ok
+}
+
+func (m *metricCache) InsertContainerThrottledMetric(containerID string, metric *ContainerThrottledMetric) error {
+	if containerID == "" {
+		return fmt.Errorf("container id is empty")
+	}
+	if metric == nil {
+		return fmt.Errorf("metric is nil")
+	}
+	m.throttledMutex.Lock()
+	defer m.throttledMutex.Unlock()
+	m.throttled[containerID] = metric
+	return nil
+}
+
+func (m *metricCache) GetLatestContainerThrottledMetric(containerID string) (*ContainerThrottledMetric, error) {
+	m.throttledMutex.RLock()
+	defer m.throttledMutex.RUnlock()
+	return m.throttled[containerID], nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containerthrottled
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/metriccache"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/metricsadvisor/framework"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/statesinformer"
+	koordletutil "github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/util"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/util/system"
+)
+
+const (
+	CollectorName = "ContainerThrottledCollector"
+)
+
+// throttledSample is the last cpu.stat reading observed for a container,
+// used to derive the deltas the adaptive burst controller consumes.
+type throttledSample struct {
+	nrPeriods     uint64
+	nrThrottled   uint64
+	throttledTime uint64
+	collectedAt   time.Time
+}
+
+// containerThrottledCollector periodically reads cpu.stat for every running
+// container and records nr_periods/nr_throttled/throttled_time deltas into
+// the MetricCache so the burst reconciler can react to real throttling.
+type containerThrottledCollector struct {
+	collectInterval time.Duration
+	statesInformer  statesinformer.StatesInformer
+	metricCache     metriccache.MetricCache
+	started         *atomic.Bool
+
+	lastSampleMutex sync.Mutex
+	lastSample      map[string]throttledSample // keyed by container ID
+}
+
+func New(opt *framework.Options) framework.Collector {
+	return &containerThrottledCollector{
+		collectInterval: opt.Config.CollectResUsedInterval,
+		statesInformer:  opt.StatesInformer,
+		metricCache:     opt.MetricCache,
+		started:         atomic.NewBool(false),
+		lastSample:      map[string]throttledSample{},
+	}
+}
+
+func (c *containerThrottledCollector) Enabled() bool {
+	return true
+}
+
+func (c *containerThrottledCollector) Setup(s *framework.Context) {}
+
+func (c *containerThrottledCollector) Run(stopCh <-chan struct{}) {
+	go wait.Until(c.collectContainerThrottled, c.collectInterval, stopCh)
+}
+
+func (c *containerThrottledCollector) Started() bool {
+	return c.started.Load()
+}
+
+func (c *containerThrottledCollector) collectContainerThrottled() {
+	started := time.Now()
+
+	for _, podMeta := range c.statesInformer.GetAllPods() {
+		if !podMeta.IsRunningOrPending() {
+			continue
+		}
+		for i := range podMeta.Pod.Status.ContainerStatuses {
+			c.collectContainerCPUStat(podMeta, &podMeta.Pod.Status.ContainerStatuses[i])
+		}
+	}
+
+	c.started.Store(true)
+	klog.V(5).Infof("collect container throttled finished, elapsed %s", time.Since(started).String())
+}
+
+func (c *containerThrottledCollector) collectContainerCPUStat(podMeta *statesinformer.PodMeta, containerStat *corev1.ContainerStatus) {
+	if containerStat.ContainerID == "" {
+		return
+	}
+	cpuStatPath, err := koordletutil.GetContainerCgroupPath(podMeta.CgroupDir, containerStat, system.CPUStatName)
+	if err != nil {
+		klog.V(5).Infof("failed to get cpu.stat path for container %v, err: %s", containerStat.ContainerID, err)
+		return
+	}
+	cur, err := readCPUStat(cpuStatPath)
+	if err != nil {
+		klog.V(5).Infof("failed to read cpu.stat for container %v, err: %s", containerStat.ContainerID, err)
+		return
+	}
+
+	c.lastSampleMutex.Lock()
+	prev, ok := c.lastSample[containerStat.ContainerID]
+	c.lastSample[containerStat.ContainerID] = cur
+	c.lastSampleMutex.Unlock()
+	if !ok {
+		return
+	}
+
+	metric := &metriccache.ContainerThrottledMetric{
+		NRPeriodsDelta:     cur.nrPeriods - prev.nrPeriods,
+		NRThrottledDelta:   cur.nrThrottled - prev.nrThrottled,
+		ThrottledTimeDelta: cur.throttledTime - prev.throttledTime,
+		Timestamp:          cur.collectedAt,
+	}
+	if err := c.metricCache.InsertContainerThrottledMetric(containerStat.ContainerID, metric); err != nil {
+		klog.Warningf("failed to insert container throttled metric for %v, err: %s", containerStat.ContainerID, err)
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containerthrottled
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// readCPUStat parses the nr_periods/nr_throttled/throttled_time fields out
+// of a cgroup cpu.stat file. Unknown fields (e.g. v2's extra accounting
+// lines) are ignored.
+func readCPUStat(path string) (throttledSample, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return throttledSample{}, err
+	}
+	defer f.Close()
+
+	sample := throttledSample{collectedAt: time.Now()}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "nr_periods":
+			sample.nrPeriods = value
+		case "nr_throttled":
+			sample.nrThrottled = value
+		case "throttled_time":
+			sample.throttledTime = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return throttledSample{}, fmt.Errorf("failed to scan %v, err: %w", path, err)
+	}
+	return sample, nil
+}
@@ -0,0 +1,27 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsadvisor
+
+import (
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/metricsadvisor/collectors/containerthrottled"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/metricsadvisor/framework"
+)
+
+// collectorPlugins lists every Collector the advisor constructs on startup.
+var collectorPlugins = map[string]func(*framework.Options) framework.Collector{
+	containerthrottled.CollectorName: containerthrottled.New,
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"time"
+
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/metriccache"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/resourceexecutor"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/statesinformer"
+)
+
+// Config holds the tunables shared by every QoS strategy, analogous to
+// metricsadvisor/framework.Config.
+type Config struct {
+	CPUBurstInterval time.Duration
+}
+
+// NewDefaultConfig returns a Config with the strategy intervals this repo
+// has always used for the CFS burst reconcile loop.
+func NewDefaultConfig() *Config {
+	return &Config{
+		CPUBurstInterval: time.Second * 10,
+	}
+}
+
+// Options are the dependencies a QoSStrategy is set up with, mirroring
+// metricsadvisor/framework.Options.
+type Options struct {
+	Config         *Config
+	StatesInformer statesinformer.StatesInformer
+	MetricCache    metriccache.MetricCache
+	Executor       resourceexecutor.ResourceUpdateExecutor
+}
+
+// Context is shared across all registered strategies, letting them serialize
+// cgroup writes through a single ResourceUpdateExecutor.
+type Context struct {
+	Strategies map[string]QoSStrategy
+	Executor   resourceexecutor.ResourceUpdateExecutor
+}
+
+// QoSStrategy is one independently pluggable QoS control loop (e.g.
+// CPUBurst, CPUSuppress, MemoryEvict, CPUEvict). Implementations are listed
+// in qosmanager.PluginRegistry.
+type QoSStrategy interface {
+	Setup(ctx *Options)
+	Run(stopCh <-chan struct{})
+	Enabled() bool
+}
+
+// StrategyFactory constructs a QoSStrategy from the shared Options.
+type StrategyFactory func(opt *Options) QoSStrategy
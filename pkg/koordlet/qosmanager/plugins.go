@@ -0,0 +1,29 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package qosmanager
+
+import (
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/qosmanager/framework"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/qosmanager/plugins/cpuburst"
+)
+
+// PluginRegistry lists every QoSStrategy the manager constructs on startup.
+// CPUSuppress, MemoryEvict and CPUEvict are expected to land here as
+// follow-up strategies behind their own feature gates.
+var PluginRegistry = map[string]framework.StrategyFactory{
+	cpuburst.StrategyName: cpuburst.New,
+}
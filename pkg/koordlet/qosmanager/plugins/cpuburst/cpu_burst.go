@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpuburst
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/features"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/metriccache"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/qosmanager/framework"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/qosmanager/plugins/cpuburst/state"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/resourceexecutor"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/statesinformer"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/util/cpuburstpolicy"
+)
+
+// StrategyName is this strategy's key in qosmanager.PluginRegistry.
+const StrategyName = "CPUBurst"
+
+// cpuBurst is the QoSStrategy replacement for the old standalone CFS burst
+// reconcile loop: it merges the NodeSLO CPUBurstStrategy (falling back to
+// the ConfigMap) with pod annotations, feeds the containerThrottledCollector
+// signal through an adaptive controller, and writes the resulting quota
+// through the shared ResourceUpdateExecutor.
+type cpuBurst struct {
+	interval       time.Duration
+	statesInformer statesinformer.StatesInformer
+	metricCache    metriccache.MetricCache
+	executor       resourceexecutor.ResourceUpdateExecutor
+	controller     *state.Controller
+}
+
+// New constructs the CPUBurst strategy. It satisfies framework.StrategyFactory.
+// The controller is state.Shared, the same instance runtimehooks releases
+// from on PostStopContainer.
+func New(opt *framework.Options) framework.QoSStrategy {
+	return &cpuBurst{
+		interval:       opt.Config.CPUBurstInterval,
+		statesInformer: opt.StatesInformer,
+		metricCache:    opt.MetricCache,
+		executor:       opt.Executor,
+		controller:     state.Shared,
+	}
+}
+
+func (b *cpuBurst) Enabled() bool {
+	return features.DefaultFeatureGate.Enabled(features.CPUBurst)
+}
+
+func (b *cpuBurst) Setup(ctx *framework.Options) {
+	b.statesInformer.RegisterCallbacks(statesinformer.RegisterTypeNodeSLOSpec, StrategyName, func(_ statesinformer.RegisterType) {
+		klog.V(4).Info("nodeSLO spec changed, cpu burst will re-reconcile on the next tick")
+	})
+}
+
+func (b *cpuBurst) Run(stopCh <-chan struct{}) {
+	go wait.Until(b.reconcile, b.interval, stopCh)
+}
+
+func (b *cpuBurst) reconcile() {
+	nodeSLO := b.statesInformer.GetNodeSLO()
+	cfsCM := b.statesInformer.GetCfsCM()
+	strategy := cpuburstpolicy.MergeNodeStrategy(nodeSLO, cfsCM)
+	if strategy == nil {
+		klog.V(5).Info("no CFS burst strategy available from NodeSLO or ConfigMap, skip reconcile")
+		return
+	}
+
+	live := map[string]struct{}{}
+	for _, podMeta := range b.statesInformer.GetAllPods() {
+		if !podMeta.IsRunningOrPending() {
+			continue
+		}
+		b.reconcilePod(podMeta, strategy, live)
+	}
+	// Containers whose stop never reached PostStopContainer (e.g. the CRI
+	// hook isn't deployed, or the node rebooted) would otherwise keep their
+	// burst state forever; prune anything not seen in this pass.
+	b.controller.Prune(live)
+}
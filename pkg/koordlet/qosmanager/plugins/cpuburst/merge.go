@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpuburst
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	slov1alpha1 "github.com/clay-wangzhi/cfs-quota-burst/pkg/apis/slo/v1alpha1"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/qosmanager/plugins/cpuburst/state"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/statesinformer"
+	koordletutil "github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/util"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/util/cpuburstpolicy"
+)
+
+func (b *cpuBurst) reconcilePod(podMeta *statesinformer.PodMeta, nodeStrategy *slov1alpha1.CPUBurstStrategy, live map[string]struct{}) {
+	qos := cpuburstpolicy.QoSClassFromLabels(podMeta.Pod.Labels)
+	if cpuburstpolicy.StrategyForQoS(nodeStrategy, qos) == nil {
+		return
+	}
+	klog.V(5).Infof("reconcile cpu burst for pod %v with qos %v", podMeta.Key(), qos)
+
+	for _, containerStat := range podMeta.Pod.Status.ContainerStatuses {
+		if containerStat.ContainerID == "" {
+			continue
+		}
+		live[state.Key(string(podMeta.Pod.UID), containerStat.Name)] = struct{}{}
+		b.reconcileContainer(podMeta, &containerStat, nodeStrategy)
+	}
+}
+
+// reconcileContainer pulls the latest throttling delta recorded by
+// containerThrottledCollector, folds it into the adaptive controller, and
+// writes the resulting quota through the shared ResourceUpdateExecutor. The
+// burst ceiling it passes to the controller is resolved by
+// cpuburstpolicy.ResolveBurstPercent, the same helper runtimehooks/rule uses
+// for the CRI proxy path.
+func (b *cpuBurst) reconcileContainer(podMeta *statesinformer.PodMeta, containerStat *corev1.ContainerStatus, nodeStrategy *slov1alpha1.CPUBurstStrategy) {
+	metric, err := b.metricCache.GetLatestContainerThrottledMetric(containerStat.ContainerID)
+	if err != nil || metric == nil {
+		return
+	}
+
+	percent := cpuburstpolicy.ResolveBurstPercent(nodeStrategy, podMeta.Pod.Labels, podMeta.Pod.Annotations)
+	maxMultiplier := float64(percent) / 100
+	multiplier := b.controller.Adjust(string(podMeta.Pod.UID), containerStat.Name, metric.NRPeriodsDelta, metric.NRThrottledDelta, maxMultiplier)
+
+	container := findContainer(podMeta.Pod, containerStat.Name)
+	if container == nil {
+		return
+	}
+	baseQuota := koordletutil.GetContainerBaseCFSQuota(container)
+	if baseQuota <= 0 {
+		return
+	}
+	burstQuota := int64(float64(baseQuota) * multiplier)
+
+	if err := koordletutil.WriteContainerCFSQuota(b.executor, podMeta.CgroupDir, containerStat, burstQuota); err != nil {
+		klog.Warningf("failed to apply cpu burst quota for container %v, err: %s", containerStat.ContainerID, err)
+	}
+}
+
+func findContainer(pod *corev1.Pod, name string) *corev1.Container {
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == name {
+			return &pod.Spec.Containers[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package state implements the adaptive CPU burst controller: it tracks a
+// per-container multiplier that grows while cpu.stat shows heavy throttling
+// and decays once the container is healthy again. It is its own package,
+// separate from cpuburst, so runtimehooks can release a container's state
+// from PostStopContainer without importing the full QoSStrategy plugin.
+package state
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// throttledRatioHighWaterMark is the threshold above which a container
+	// is considered meaningfully throttled and should grow its burst quota.
+	throttledRatioHighWaterMark = 0.10
+	// throttledRatioLowWaterMark is the threshold below which a container is
+	// considered healthy; staying under it for decayWindowsToReset
+	// consecutive windows decays the multiplier back toward 1.0.
+	throttledRatioLowWaterMark = 0.01
+	// burstGrowthFactor is how much the multiplier grows per window while a
+	// container is throttled above the high-water mark.
+	burstGrowthFactor = 1.5
+	// burstDecayFactor is how much the multiplier shrinks per window once a
+	// container has been healthy for decayWindowsToReset windows.
+	burstDecayFactor = 0.8
+	// decayWindowsToReset is the number of consecutive low-throttling
+	// windows required before the multiplier starts decaying.
+	decayWindowsToReset = 3
+	// baseMultiplier is the floor and starting point for every container.
+	baseMultiplier = 1.0
+)
+
+// DefaultCheckpointPath is where Shared persists its state between koordlet
+// restarts, so a restart doesn't reset every container's multiplier back to
+// baseMultiplier and re-trigger the throttling spike it was compensating for.
+const DefaultCheckpointPath = "/var/run/koordlet/cpu-burst-state.checkpoint"
+
+// containerState is one container's persisted adaptive-controller state,
+// keyed by pod UID plus container name. When the runtime hook server is
+// enabled, PostStopContainer releases it, so a restarted container starts
+// again from baseMultiplier.
+type containerState struct {
+	Multiplier      float64 `json:"multiplier"`
+	LowWindowStreak int     `json:"lowWindowStreak"`
+}
+
+// Controller implements the closed-loop quota adjustment described in the
+// adaptive burst design: grow the multiplier exponentially while a
+// container is throttled, decay it back toward baseMultiplier once healthy,
+// and checkpoint every multiplier change so it survives a koordlet restart.
+type Controller struct {
+	checkpointPath string
+
+	mutex sync.Mutex
+	// byKey is keyed by "<podUID>/<containerName>".
+	byKey map[string]*containerState
+}
+
+// Shared is the process-wide controller used by both the CPUBurst
+// reconciler and the runtimehooks CRI proxy hook.
+var Shared = NewController(DefaultCheckpointPath)
+
+// NewController builds a Controller, loading any existing checkpoint at
+// checkpointPath. Tests can pass a throwaway path to avoid touching
+// DefaultCheckpointPath.
+func NewController(checkpointPath string) *Controller {
+	c := &Controller{
+		checkpointPath: checkpointPath,
+		byKey:          map[string]*containerState{},
+	}
+	c.load()
+	return c
+}
+
+func key(podUID, containerName string) string {
+	return podUID + "/" + containerName
+}
+
+func (c *Controller) load() {
+	raw, err := os.ReadFile(c.checkpointPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			klog.Warningf("failed to read cpu burst checkpoint %v, err: %s", c.checkpointPath, err)
+		}
+		return
+	}
+	checkpoint := map[string]*containerState{}
+	if err := json.Unmarshal(raw, &checkpoint); err != nil {
+		klog.Warningf("failed to parse cpu burst checkpoint %v, err: %s", c.checkpointPath, err)
+		return
+	}
+	c.byKey = checkpoint
+}
+
+// persist must be called with c.mutex held.
+func (c *Controller) persist() {
+	raw, err := json.Marshal(c.byKey)
+	if err != nil {
+		klog.Warningf("failed to marshal cpu burst checkpoint, err: %s", err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.checkpointPath), 0755); err != nil {
+		klog.Warningf("failed to create cpu burst checkpoint dir %v, err: %s", filepath.Dir(c.checkpointPath), err)
+		return
+	}
+	if err := os.WriteFile(c.checkpointPath, raw, 0644); err != nil {
+		klog.Warningf("failed to write cpu burst checkpoint %v, err: %s", c.checkpointPath, err)
+	}
+}
+
+// Adjust folds a new (deltaNRPeriods, deltaNRThrottled) observation into the
+// container's persisted state and returns the burst multiplier to apply,
+// capped at maxMultiplier.
+func (c *Controller) Adjust(podUID, containerName string, deltaNRPeriods, deltaNRThrottled uint64, maxMultiplier float64) float64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	k := key(podUID, containerName)
+	st, ok := c.byKey[k]
+	if !ok {
+		st = &containerState{Multiplier: baseMultiplier}
+		c.byKey[k] = st
+	}
+
+	if maxMultiplier < baseMultiplier {
+		maxMultiplier = baseMultiplier
+	}
+
+	if deltaNRPeriods == 0 {
+		if st.Multiplier > maxMultiplier {
+			return maxMultiplier
+		}
+		return st.Multiplier
+	}
+	ratio := float64(deltaNRThrottled) / float64(deltaNRPeriods)
+
+	dirty := false
+	switch {
+	case ratio > throttledRatioHighWaterMark:
+		st.LowWindowStreak = 0
+		st.Multiplier *= burstGrowthFactor
+		if st.Multiplier > maxMultiplier {
+			st.Multiplier = maxMultiplier
+		}
+		burstAdjustmentsTotal.WithLabelValues(podUID, containerName, "grow").Inc()
+		dirty = true
+	case ratio < throttledRatioLowWaterMark:
+		st.LowWindowStreak++
+		if st.LowWindowStreak >= decayWindowsToReset {
+			st.Multiplier *= burstDecayFactor
+			if st.Multiplier < baseMultiplier {
+				st.Multiplier = baseMultiplier
+			}
+			burstAdjustmentsTotal.WithLabelValues(podUID, containerName, "decay").Inc()
+			dirty = true
+		}
+	default:
+		st.LowWindowStreak = 0
+	}
+	if st.Multiplier > maxMultiplier {
+		st.Multiplier = maxMultiplier
+		dirty = true
+	}
+
+	throttledRatioGauge.WithLabelValues(podUID, containerName).Set(ratio)
+	burstMultiplierGauge.WithLabelValues(podUID, containerName).Set(st.Multiplier)
+
+	if dirty {
+		c.persist()
+	}
+	return st.Multiplier
+}
+
+// Release drops a container's burst state, called from PostStopContainer so
+// state doesn't accumulate forever for containers that have stopped.
+func (c *Controller) Release(podUID, containerName string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	k := key(podUID, containerName)
+	if _, ok := c.byKey[k]; !ok {
+		return
+	}
+	delete(c.byKey, k)
+	c.persist()
+	burstMultiplierGauge.DeleteLabelValues(podUID, containerName)
+	throttledRatioGauge.DeleteLabelValues(podUID, containerName)
+}
+
+// Prune drops every container's state whose key isn't in live. The CPUBurst
+// reconciler calls this each tick with the running pods/containers it just
+// saw, as a backstop for containers whose stop never reached
+// PostStopContainer (e.g. the node rebooting, or a non-CRI-hook deployment).
+func (c *Controller) Prune(live map[string]struct{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	dirty := false
+	for k := range c.byKey {
+		if _, ok := live[k]; ok {
+			continue
+		}
+		delete(c.byKey, k)
+		dirty = true
+	}
+	if dirty {
+		c.persist()
+	}
+}
+
+// Key re-exports the controller's "<podUID>/<containerName>" keying so
+// callers building a live set for Prune use the same format.
+func Key(podUID, containerName string) string {
+	return key(podUID, containerName)
+}
+
+var (
+	burstMultiplierGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "koordlet",
+		Subsystem: "cpu_burst",
+		Name:      "multiplier",
+		Help:      "current CFS quota burst multiplier applied to a container's base quota",
+	}, []string{"pod_uid", "container_name"})
+
+	throttledRatioGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "koordlet",
+		Subsystem: "cpu_burst",
+		Name:      "throttled_ratio",
+		Help:      "nr_throttled / nr_periods observed over the last reconcile window",
+	}, []string{"pod_uid", "container_name"})
+
+	burstAdjustmentsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "koordlet",
+		Subsystem: "cpu_burst",
+		Name:      "adjustments_total",
+		Help:      "number of times the adaptive controller changed a container's burst multiplier",
+	}, []string{"pod_uid", "container_name", "direction"})
+)
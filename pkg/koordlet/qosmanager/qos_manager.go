@@ -0,0 +1,82 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package qosmanager
+
+import (
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/klog/v2"
+
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/metriccache"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/qosmanager/framework"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/resourceexecutor"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/statesinformer"
+)
+
+// QoSManager runs the registered QoSStrategy plugins, replacing the old
+// single-purpose CFS burst reconcile loop.
+type QoSManager interface {
+	Run(stopCh <-chan struct{}) error
+}
+
+type qosManager struct {
+	options *framework.Options
+	context *framework.Context
+}
+
+// NewQoSManager wires up every strategy in PluginRegistry, analogous to how
+// NewMetricAdvisor wires up collectorPlugins.
+func NewQoSManager(cfg *framework.Config, statesInformer statesinformer.StatesInformer, metricCache metriccache.MetricCache) QoSManager {
+	opt := &framework.Options{
+		Config:         cfg,
+		StatesInformer: statesInformer,
+		MetricCache:    metricCache,
+		Executor:       resourceexecutor.NewResourceUpdateExecutor(),
+	}
+	ctx := &framework.Context{
+		Strategies: make(map[string]framework.QoSStrategy, len(PluginRegistry)),
+		Executor:   opt.Executor,
+	}
+	for name, newStrategy := range PluginRegistry {
+		ctx.Strategies[name] = newStrategy(opt)
+	}
+
+	return &qosManager{
+		options: opt,
+		context: ctx,
+	}
+}
+
+func (m *qosManager) Run(stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+
+	m.options.Executor.Run(stopCh)
+
+	for name, strategy := range m.context.Strategies {
+		if !strategy.Enabled() {
+			klog.V(4).Infof("qos strategy %v is not enabled, skip running", name)
+			continue
+		}
+		strategy.Setup(m.options)
+		go strategy.Run(stopCh)
+		klog.V(4).Infof("qos strategy %v started", name)
+	}
+
+	klog.Info("qos manager started successfully")
+	<-stopCh
+	klog.Info("shutting down qos manager")
+	return nil
+}
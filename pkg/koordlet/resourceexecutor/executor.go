@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resourceexecutor is the single place every QoSStrategy writes
+// cgroup files through, so writes from different strategies are serialized
+// under one lock instead of interleaving. Every update is written in turn;
+// updates to the same file are not merged.
+package resourceexecutor
+
+import (
+	"os"
+	"sync"
+)
+
+// ResourceUpdater is a single pending write to a cgroup file.
+type ResourceUpdater interface {
+	// Key is the path of the file this update writes to.
+	Key() string
+	Value() []byte
+}
+
+type fileUpdater struct {
+	path    string
+	content []byte
+}
+
+// NewFileUpdater builds a ResourceUpdater that writes content to path.
+func NewFileUpdater(path string, content []byte) ResourceUpdater {
+	return &fileUpdater{path: path, content: content}
+}
+
+func (f *fileUpdater) Key() string {
+	return f.path
+}
+
+func (f *fileUpdater) Value() []byte {
+	return f.content
+}
+
+// ResourceUpdateExecutor serializes cgroup file writes requested by every
+// QoSStrategy sharing a qosmanager.Context.
+type ResourceUpdateExecutor interface {
+	Run(stopCh <-chan struct{})
+	Update(updater ResourceUpdater) error
+}
+
+type executor struct {
+	mutex sync.Mutex
+}
+
+// NewResourceUpdateExecutor returns the shared executor a QoSManager hands
+// to every registered strategy.
+func NewResourceUpdateExecutor() ResourceUpdateExecutor {
+	return &executor{}
+}
+
+// Run currently has nothing to start in the background: Update writes
+// synchronously under the executor's lock. It exists so strategies depend on
+// the executor's lifecycle rather than on os.WriteFile directly, letting a
+// future change move to a batched/async flush without touching callers.
+func (e *executor) Run(stopCh <-chan struct{}) {
+}
+
+func (e *executor) Update(updater ResourceUpdater) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return os.WriteFile(updater.Key(), updater.Value(), 0644)
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package api is the wire contract between the CRI proxy (containerd's NRI
+// plugin or the CRI-O hook shim) and koordlet's runtime hook server,
+// matching runtimehooks.proto. Messages are plain Go structs exchanged
+// through jsonCodec rather than protoc-gen-go stubs.
+package api
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// ContainerResourceHookRequest carries everything the hook needs to compute
+// a container's intended CPU burst quota without waiting for a reconcile
+// pass to observe the container.
+type ContainerResourceHookRequest struct {
+	PodUID                 string            `json:"pod_uid"`
+	PodName                string            `json:"pod_name"`
+	PodNamespace           string            `json:"pod_namespace"`
+	PodLabels              map[string]string `json:"pod_labels"`
+	PodAnnotations         map[string]string `json:"pod_annotations"`
+	ContainerName          string            `json:"container_name"`
+	ContainerMilliCPULimit int64             `json:"container_milli_cpu_limit"`
+}
+
+// ContainerResourceHookResponse is the quota the hook server wants applied
+// before the container is started.
+type ContainerResourceHookResponse struct {
+	// CFSQuotaUS is the cpu.cfs_quota_us (or v2 cpu.max quota) to set, -1
+	// meaning unlimited.
+	CFSQuotaUS int64 `json:"cfs_quota_us"`
+	// CFSPeriodUS is the cpu.cfs_period_us (or v2 cpu.max period) to set.
+	CFSPeriodUS int64 `json:"cfs_period_us"`
+}
+
+// PostStopContainerResponse is empty; PostStopContainer only ever fails or
+// succeeds.
+type PostStopContainerResponse struct{}
+
+// RuntimeHookServiceServer is implemented by the hook registry and invoked
+// by the CRI proxy at the three points in a container's lifecycle where
+// burst quota needs to be applied or cleaned up.
+type RuntimeHookServiceServer interface {
+	// PreCreateContainer is called before the CRI runtime creates the
+	// container's cgroup, letting the hook set the initial burst quota
+	// before the container ever runs at its base quota.
+	PreCreateContainer(ctx context.Context, req *ContainerResourceHookRequest) (*ContainerResourceHookResponse, error)
+	// PreStartContainer is called after creation but before the container
+	// process starts, as a fallback point for runtimes that don't expose a
+	// pre-create hook.
+	PreStartContainer(ctx context.Context, req *ContainerResourceHookRequest) (*ContainerResourceHookResponse, error)
+	// PostStopContainer lets the hook registry release any per-container
+	// state (e.g. the adaptive burst controller's persisted multiplier).
+	PostStopContainer(ctx context.Context, req *ContainerResourceHookRequest) (*PostStopContainerResponse, error)
+}
+
+// serviceDesc is the grpc.ServiceDesc a protoc-gen-go-grpc run against
+// runtimehooks.proto would emit; it is hand-written here since these
+// messages round-trip through jsonCodec instead of protobuf.
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: "runtimehooks.RuntimeHookService",
+	HandlerType: (*RuntimeHookServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "PreCreateContainer", Handler: preCreateContainerHandler},
+		{MethodName: "PreStartContainer", Handler: preStartContainerHandler},
+		{MethodName: "PostStopContainer", Handler: postStopContainerHandler},
+	},
+	Metadata: "runtimehooks.proto",
+}
+
+// RegisterRuntimeHookServiceServer registers srv's three RPCs on s.
+func RegisterRuntimeHookServiceServer(s *grpc.Server, srv RuntimeHookServiceServer) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+func preCreateContainerHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContainerResourceHookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeHookServiceServer).PreCreateContainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/runtimehooks.RuntimeHookService/PreCreateContainer"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeHookServiceServer).PreCreateContainer(ctx, req.(*ContainerResourceHookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func preStartContainerHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContainerResourceHookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeHookServiceServer).PreStartContainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/runtimehooks.RuntimeHookService/PreStartContainer"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeHookServiceServer).PreStartContainer(ctx, req.(*ContainerResourceHookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func postStopContainerHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContainerResourceHookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeHookServiceServer).PostStopContainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/runtimehooks.RuntimeHookService/PostStopContainer"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeHookServiceServer).PostStopContainer(ctx, req.(*ContainerResourceHookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// codecName is the name jsonCodec registers under. It must not be "proto":
+// that's the name grpc-go's built-in protobuf codec uses and is the
+// process-wide fallback whenever a call doesn't request a content-subtype,
+// so squatting on it would silently break every other gRPC client/server in
+// the koordlet binary (e.g. the CRI/containerd client).
+const codecName = "runtimehooks-json"
+
+// jsonCodec lets RuntimeHookServiceServer exchange plain Go structs over
+// gRPC instead of requiring protoc-gen-go message types, since this socket
+// has exactly one client (the CRI proxy) and one server (koordlet) and
+// doesn't need cross-language protobuf compatibility.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return codecName
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// CallContentSubtype is the grpc.CallOption the CRI proxy client must pass
+// on every call so it negotiates jsonCodec instead of falling back to the
+// process default protobuf codec.
+func CallContentSubtype() grpc.CallOption {
+	return grpc.CallContentSubtype(codecName)
+}
+
+// ServerCodecOption is the grpc.ServerOption server.go passes to grpc.NewServer
+// so this socket always decodes with jsonCodec, regardless of whether the
+// connecting client remembered to set CallContentSubtype.
+func ServerCodecOption() grpc.ServerOption {
+	return grpc.ForceServerCodec(jsonCodec{})
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtimehooks
+
+// RuntimeHooksConfig configures the CRI proxy hook server. When Enabled is
+// false, burst quota is only ever applied by the qosmanager's periodic
+// reconciler, as it was before this subsystem existed.
+type RuntimeHooksConfig struct {
+	// Enabled turns the CRI proxy hook server on. Hosts whose runtime does
+	// not support the proxy (no containerd/CRI-O hook support compiled in)
+	// should leave this false and rely on reconciler mode.
+	Enabled bool
+	// UnixSocketPath is the CRI proxy hook socket this server listens on,
+	// e.g. /var/run/koordlet/runtimehooks.sock.
+	UnixSocketPath string
+}
+
+// NewDefaultConfig returns a RuntimeHooksConfig with hooks disabled, i.e.
+// reconciler-only mode, which matches this repo's behavior prior to the
+// runtime hooks subsystem.
+func NewDefaultConfig() *RuntimeHooksConfig {
+	return &RuntimeHooksConfig{
+		Enabled:        false,
+		UnixSocketPath: "/var/run/koordlet/runtimehooks.sock",
+	}
+}
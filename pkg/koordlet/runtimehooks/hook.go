@@ -0,0 +1,69 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtimehooks
+
+import (
+	"context"
+
+	"k8s.io/klog/v2"
+
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/qosmanager/plugins/cpuburst/state"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/runtimehooks/api"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/runtimehooks/rule"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/statesinformer"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/util/system"
+)
+
+// burstHook implements api.RuntimeHookServiceServer, computing the burst
+// quota for a container at the three lifecycle points the CRI proxy calls
+// into. This removes the race window where a bursty container runs at its
+// base quota for one reconcile interval before the periodic reconciler
+// catches up.
+type burstHook struct {
+	rules *rule.Registry
+}
+
+func newBurstHook(statesInformer statesinformer.StatesInformer) api.RuntimeHookServiceServer {
+	return &burstHook{
+		rules: &rule.Registry{StatesInformer: statesInformer},
+	}
+}
+
+func (h *burstHook) PreCreateContainer(ctx context.Context, req *api.ContainerResourceHookRequest) (*api.ContainerResourceHookResponse, error) {
+	return h.resolve(req)
+}
+
+func (h *burstHook) PreStartContainer(ctx context.Context, req *api.ContainerResourceHookRequest) (*api.ContainerResourceHookResponse, error) {
+	return h.resolve(req)
+}
+
+func (h *burstHook) PostStopContainer(ctx context.Context, req *api.ContainerResourceHookRequest) (*api.PostStopContainerResponse, error) {
+	state.Shared.Release(req.PodUID, req.ContainerName)
+	klog.V(4).Infof("released cpu burst state for stopped container %v/%v", req.PodName, req.ContainerName)
+	return &api.PostStopContainerResponse{}, nil
+}
+
+func (h *burstHook) resolve(req *api.ContainerResourceHookRequest) (*api.ContainerResourceHookResponse, error) {
+	baseQuotaUS := system.MilliCPUToQuota(req.ContainerMilliCPULimit)
+	if baseQuotaUS <= 0 {
+		return &api.ContainerResourceHookResponse{CFSQuotaUS: baseQuotaUS, CFSPeriodUS: system.DefaultCFSPeriodUS}, nil
+	}
+
+	quotaUS := h.rules.ResolveCFSQuota(baseQuotaUS, req.PodLabels, req.PodAnnotations)
+	klog.V(4).Infof("resolved cpu burst quota for %v/%v: base=%v quota=%v", req.PodName, req.ContainerName, baseQuotaUS, quotaUS)
+	return &api.ContainerResourceHookResponse{CFSQuotaUS: quotaUS, CFSPeriodUS: system.DefaultCFSPeriodUS}, nil
+}
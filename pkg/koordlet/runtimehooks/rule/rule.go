@@ -0,0 +1,45 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rule computes the CPU burst quota a runtime hook should apply to a
+// container, consulting the same cpuburstpolicy helpers the qosmanager
+// reconciler uses.
+package rule
+
+import (
+	slov1alpha1 "github.com/clay-wangzhi/cfs-quota-burst/pkg/apis/slo/v1alpha1"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/statesinformer"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/util/cpuburstpolicy"
+)
+
+// Registry resolves the CPU burst quota for a container from the current
+// NodeSLO/ConfigMap policy and the pod's own annotations.
+type Registry struct {
+	StatesInformer statesinformer.StatesInformer
+}
+
+// ResolveCFSQuota returns the quota (in microseconds) that should be written
+// for a container with the given base quota, podLabels and podAnnotations.
+func (r *Registry) ResolveCFSQuota(baseQuotaUS int64, podLabels, podAnnotations map[string]string) int64 {
+	return cpuburstpolicy.ResolveCFSQuota(baseQuotaUS, r.nodeStrategy(), podLabels, podAnnotations)
+}
+
+func (r *Registry) nodeStrategy() *slov1alpha1.CPUBurstStrategy {
+	if r.StatesInformer == nil {
+		return nil
+	}
+	return cpuburstpolicy.MergeNodeStrategy(r.StatesInformer.GetNodeSLO(), r.StatesInformer.GetCfsCM())
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtimehooks
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"google.golang.org/grpc"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/klog/v2"
+
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/runtimehooks/api"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/statesinformer"
+)
+
+// RuntimeHooks runs the CRI proxy hook server when enabled. Run is a no-op
+// when the config disables it, leaving burst quota entirely to the
+// qosmanager reconciler, i.e. reconciler mode.
+type RuntimeHooks interface {
+	Run(stopCh <-chan struct{}) error
+}
+
+type runtimeHooks struct {
+	config         *RuntimeHooksConfig
+	statesInformer statesinformer.StatesInformer
+}
+
+// NewRuntimeHooks constructs the hook server. Callers that do not have a
+// CRI proxy available on the host should still construct it and rely on
+// RuntimeHooksConfig.Enabled=false to fall back to reconciler mode.
+func NewRuntimeHooks(config *RuntimeHooksConfig, statesInformer statesinformer.StatesInformer) RuntimeHooks {
+	return &runtimeHooks{
+		config:         config,
+		statesInformer: statesInformer,
+	}
+}
+
+func (r *runtimeHooks) Run(stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+
+	if !r.config.Enabled {
+		klog.Info("runtime hooks server is disabled, burst quota will only be applied by the qosmanager reconciler")
+		return nil
+	}
+
+	if err := os.RemoveAll(r.config.UnixSocketPath); err != nil {
+		return fmt.Errorf("failed to clear stale runtime hooks socket %v, err: %w", r.config.UnixSocketPath, err)
+	}
+	listener, err := net.Listen("unix", r.config.UnixSocketPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %v, err: %w", r.config.UnixSocketPath, err)
+	}
+
+	grpcServer := grpc.NewServer(api.ServerCodecOption())
+	api.RegisterRuntimeHookServiceServer(grpcServer, newBurstHook(r.statesInformer))
+
+	go func() {
+		klog.Infof("starting runtime hooks server on %v", r.config.UnixSocketPath)
+		if err := grpcServer.Serve(listener); err != nil {
+			klog.Errorf("runtime hooks server stopped serving, err: %s", err)
+		}
+	}()
+
+	<-stopCh
+	klog.Info("shutting down runtime hooks server")
+	grpcServer.GracefulStop()
+	return nil
+}
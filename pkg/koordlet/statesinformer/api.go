@@ -19,6 +19,7 @@ package statesinformer
 import (
 	corev1 "k8s.io/api/core/v1"
 
+	slov1alpha1 "github.com/clay-wangzhi/cfs-quota-burst/pkg/apis/slo/v1alpha1"
 	"github.com/clay-wangzhi/cfs-quota-burst/pkg/util"
 )
 
@@ -54,6 +55,7 @@ type RegisterType int64
 const (
 	RegisterTypeAllPods RegisterType = iota
 	RegisterTypeNodeMetadata
+	RegisterTypeNodeSLOSpec
 )
 
 func (r RegisterType) String() string {
@@ -62,15 +64,34 @@ func (r RegisterType) String() string {
 		return "RegisterTypeAllPods"
 	case RegisterTypeNodeMetadata:
 		return "RegisterNodeMetadata"
+	case RegisterTypeNodeSLOSpec:
+		return "RegisterTypeNodeSLOSpec"
 	default:
 		return "RegisterTypeUnknown"
 	}
 }
 
+// UpdateCbFn is invoked by an informer plugin whenever the data it owns changes.
+// The RegisterType identifies which callback table the function was registered
+// under so a single handler can be reused across event sources if needed.
+type UpdateCbFn func(t RegisterType)
+
+// UpdateCbCtx carries the name and handler of a registered callback so it can
+// be logged and looked up by the states informer.
+type UpdateCbCtx struct {
+	Name string
+	Fn   UpdateCbFn
+}
+
 type StatesInformer interface {
 	Run(stopCh <-chan struct{}) error
 	HasSynced() bool
 	GetNode() *corev1.Node
 	GetCfsCM() *corev1.ConfigMap
+	GetNodeSLO() *slov1alpha1.NodeSLO
 	GetAllPods() []*PodMeta
+	// RegisterCallbacks registers fn under the given RegisterType so it is
+	// invoked whenever the corresponding informer plugin observes a change.
+	// name is used for logging and must be unique per RegisterType.
+	RegisterCallbacks(objType RegisterType, name string, fn UpdateCbFn)
 }
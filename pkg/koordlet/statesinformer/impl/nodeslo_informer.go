@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	slov1alpha1 "github.com/clay-wangzhi/cfs-quota-burst/pkg/apis/slo/v1alpha1"
+	koordclientset "github.com/clay-wangzhi/cfs-quota-burst/pkg/client/clientset/versioned"
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/statesinformer"
+)
+
+const (
+	nodeSLOInformerName PluginName = "nodeSLOInformer"
+
+	nodeSLOInformerSyncPeriod = time.Minute
+)
+
+// nodeSLOInformer watches the NodeSLO CRD instance owned by this node and
+// becomes the authoritative source of CFS burst policy, replacing the flat
+// ConfigMap read by cmInformer. It keeps GetCfsCM as a fallback for nodes
+// that have not yet been given a NodeSLO.
+type nodeSLOInformer struct {
+	informer cache.SharedIndexInformer
+
+	rwMutex sync.RWMutex
+	nodeSLO *slov1alpha1.NodeSLO
+
+	callbacksMutex sync.RWMutex
+	callbacks      []statesinformer.UpdateCbCtx
+
+	synced *atomic.Bool
+}
+
+func init() {
+	DefaultPluginRegistry[nodeSLOInformerName] = &nodeSLOInformer{
+		synced: atomic.NewBool(false),
+	}
+}
+
+func (s *nodeSLOInformer) Setup(ctx *PluginOption, state *PluginState) {
+	nodeSLOInformer := newNodeSLOInformer(ctx.KoordClient, ctx.NodeName)
+	nodeSLOInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    s.updateNodeSLO,
+		UpdateFunc: func(oldObj, newObj interface{}) { s.updateNodeSLO(newObj) },
+		DeleteFunc: s.deleteNodeSLO,
+	})
+	s.informer = nodeSLOInformer
+}
+
+func (s *nodeSLOInformer) Start(stopCh <-chan struct{}) {
+	klog.V(2).Infof("starting nodeSLO informer")
+	go s.informer.Run(stopCh)
+	if !cache.WaitForCacheSync(stopCh, s.informer.HasSynced) {
+		klog.Errorf("timed out waiting for nodeSLO informer cache to sync")
+		return
+	}
+	s.synced.Store(true)
+	klog.V(2).Infof("nodeSLO informer started")
+}
+
+func (s *nodeSLOInformer) HasSynced() bool {
+	return s.synced.Load()
+}
+
+func (s *nodeSLOInformer) GetNodeSLO() *slov1alpha1.NodeSLO {
+	s.rwMutex.RLock()
+	defer s.rwMutex.RUnlock()
+	return s.nodeSLO
+}
+
+// RegisterCallback registers fn to be invoked whenever the NodeSLO spec
+// changes. name must be unique and is only used for logging.
+func (s *nodeSLOInformer) RegisterCallback(name string, fn statesinformer.UpdateCbFn) {
+	s.callbacksMutex.Lock()
+	defer s.callbacksMutex.Unlock()
+	s.callbacks = append(s.callbacks, statesinformer.UpdateCbCtx{Name: name, Fn: fn})
+}
+
+func (s *nodeSLOInformer) updateNodeSLO(obj interface{}) {
+	nodeSLO, ok := obj.(*slov1alpha1.NodeSLO)
+	if !ok {
+		klog.Errorf("nodeSLO informer received unexpected object type %T", obj)
+		return
+	}
+	s.rwMutex.Lock()
+	s.nodeSLO = nodeSLO.DeepCopy()
+	s.rwMutex.Unlock()
+	klog.V(4).Infof("nodeSLO %v updated", nodeSLO.Name)
+	s.runCallbacks()
+}
+
+func (s *nodeSLOInformer) deleteNodeSLO(obj interface{}) {
+	s.rwMutex.Lock()
+	s.nodeSLO = nil
+	s.rwMutex.Unlock()
+	klog.V(2).Infof("nodeSLO deleted, burst policy will fall back to the ConfigMap")
+	s.runCallbacks()
+}
+
+// newNodeSLOInformer builds a SharedIndexInformer watching the single
+// NodeSLO instance named after this node, since NodeSLO is a cluster-scoped
+// resource named 1:1 with the Node it describes.
+func newNodeSLOInformer(koordClient koordclientset.Interface, nodeName string) cache.SharedIndexInformer {
+	nameSelector := fields.OneTermEqualSelector("metadata.name", nodeName).String()
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				options.FieldSelector = nameSelector
+				return koordClient.SloV1alpha1().NodeSLOs().List(options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.FieldSelector = nameSelector
+				return koordClient.SloV1alpha1().NodeSLOs().Watch(options)
+			},
+		},
+		&slov1alpha1.NodeSLO{},
+		nodeSLOInformerSyncPeriod,
+		cache.Indexers{},
+	)
+}
+
+func (s *nodeSLOInformer) runCallbacks() {
+	s.callbacksMutex.RLock()
+	defer s.callbacksMutex.RUnlock()
+	for _, cb := range s.callbacks {
+		klog.V(4).Infof("run callback %v for nodeSLO spec update", cb.Name)
+		cb.Fn(statesinformer.RegisterTypeNodeSLOSpec)
+	}
+}
@@ -26,6 +26,8 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
+	slov1alpha1 "github.com/clay-wangzhi/cfs-quota-burst/pkg/apis/slo/v1alpha1"
+	koordclientset "github.com/clay-wangzhi/cfs-quota-burst/pkg/client/clientset/versioned"
 	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/statesinformer"
 )
 
@@ -39,15 +41,18 @@ type StatesInformer interface {
 	HasSynced() bool
 	GetNode() *corev1.Node
 	GetCfsCM() *corev1.ConfigMap
+	GetNodeSLO() *slov1alpha1.NodeSLO
 	GetAllPods() []*statesinformer.PodMeta
+	RegisterCallbacks(objType statesinformer.RegisterType, name string, fn statesinformer.UpdateCbFn)
 }
 
 type PluginName string
 
 type PluginOption struct {
-	config     *Config
-	KubeClient clientset.Interface
-	NodeName   string
+	config      *Config
+	KubeClient  clientset.Interface
+	KoordClient koordclientset.Interface
+	NodeName    string
 }
 
 type PluginState struct {
@@ -68,12 +73,13 @@ type informerPlugin interface {
 }
 
 // TODO merge all clients into one struct
-func NewStatesInformer(config *Config, kubeClient clientset.Interface,
+func NewStatesInformer(config *Config, kubeClient clientset.Interface, koordClient koordclientset.Interface,
 	nodeName string) StatesInformer {
 	opt := &PluginOption{
-		config:     config,
-		KubeClient: kubeClient,
-		NodeName:   nodeName,
+		config:      config,
+		KubeClient:  kubeClient,
+		KoordClient: koordClient,
+		NodeName:    nodeName,
 	}
 	stat := &PluginState{
 		informerPlugins: map[PluginName]informerPlugin{},
@@ -174,3 +180,28 @@ func (s *statesInformer) GetAllPods() []*statesinformer.PodMeta {
 	}
 	return podsInformer.GetAllPods()
 }
+
+func (s *statesInformer) GetNodeSLO() *slov1alpha1.NodeSLO {
+	nodeSLOInformerIf := s.states.informerPlugins[nodeSLOInformerName]
+	sloInformer, ok := nodeSLOInformerIf.(*nodeSLOInformer)
+	if !ok {
+		klog.Errorf("nodeSLO informer format error")
+		return nil
+	}
+	return sloInformer.GetNodeSLO()
+}
+
+func (s *statesInformer) RegisterCallbacks(objType statesinformer.RegisterType, name string, fn statesinformer.UpdateCbFn) {
+	switch objType {
+	case statesinformer.RegisterTypeNodeSLOSpec:
+		nodeSLOInformerIf := s.states.informerPlugins[nodeSLOInformerName]
+		sloInformer, ok := nodeSLOInformerIf.(*nodeSLOInformer)
+		if !ok {
+			klog.Errorf("nodeSLO informer format error, cannot register callback %v", name)
+			return
+		}
+		sloInformer.RegisterCallback(name, fn)
+	default:
+		klog.Errorf("callback register type %v is not supported", objType)
+	}
+}
@@ -20,9 +20,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
 
+	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/resourceexecutor"
 	"github.com/clay-wangzhi/cfs-quota-burst/pkg/koordlet/util/system"
 	"github.com/clay-wangzhi/cfs-quota-burst/pkg/util"
 )
@@ -64,6 +66,38 @@ func GetContainerBaseCFSQuota(container *corev1.Container) int64 {
 	return system.MilliCPUToQuota(cpuMilliLimit)
 }
 
+// WriteContainerCFSQuota sets a container's CFS bandwidth quota (in
+// microseconds), transparently handling both cgroup hierarchies: under v1 it
+// writes cpu.cfs_quota_us directly, under v2 it re-reads the current period
+// out of cpu.max and rewrites the "<quota> <period>" pair atomically so the
+// two values are never observed out of sync. The write itself goes through
+// executor so it is serialized with every other strategy's cgroup updates
+// instead of racing them.
+func WriteContainerCFSQuota(executor resourceexecutor.ResourceUpdateExecutor, podParentDir string, c *corev1.ContainerStatus, quotaUS int64) error {
+	if system.GetCurrentCgroupVersion() == system.CgroupVersionV2 {
+		path, err := GetContainerCgroupPath(podParentDir, c, system.CPUMaxName)
+		if err != nil {
+			return fmt.Errorf("failed to get cpu.max path, err: %w", err)
+		}
+		rawContent, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %v, err: %w", path, err)
+		}
+		cpuMax, err := system.ParseCPUMax(string(rawContent))
+		if err != nil {
+			return fmt.Errorf("failed to parse %v, err: %w", path, err)
+		}
+		cpuMax.QuotaUS = quotaUS
+		return executor.Update(resourceexecutor.NewFileUpdater(path, []byte(cpuMax.String())))
+	}
+
+	path, err := GetContainerCgroupPath(podParentDir, c, system.CPUCFSQuotaName)
+	if err != nil {
+		return fmt.Errorf("failed to get cpu.cfs_quota_us path, err: %w", err)
+	}
+	return executor.Update(resourceexecutor.NewFileUpdater(path, []byte(strconv.FormatInt(quotaUS, 10))))
+}
+
 // ParseContainerID parse container ID from the container base path.
 // e.g. 7712555c_ce62_454a_9e18_9ff0217b8941 from docker-7712555c_ce62_454a_9e18_9ff0217b8941.scope
 func ParseContainerID(basename string) (string, error) {
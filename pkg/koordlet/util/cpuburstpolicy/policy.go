@@ -0,0 +1,141 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cpuburstpolicy resolves the effective CFS quota burst percent for
+// a container from NodeSLO/ConfigMap node policy and pod annotations. Both
+// the qosmanager CPUBurst reconciler and the runtimehooks CRI proxy hook
+// compute it through here.
+package cpuburstpolicy
+
+import (
+	"encoding/json"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	slov1alpha1 "github.com/clay-wangzhi/cfs-quota-burst/pkg/apis/slo/v1alpha1"
+)
+
+const (
+	// CfsCMDataKey is the ConfigMap key the legacy flat burst policy was
+	// stored under before the NodeSLO CRD became the authoritative source.
+	CfsCMDataKey = "cfs-quota-burst-config"
+
+	// PodCFSQuotaBurstPercentAnnotation lets a pod override the node's
+	// default burst percent, taking precedence over both NodeSLO and the
+	// ConfigMap.
+	PodCFSQuotaBurstPercentAnnotation = "koordinator.sh/cfsQuotaBurstPercent"
+
+	// PodQoSClassLabel is the koordinator QoS class label used to select a
+	// CPUBurstStrategy.QoSStrategies override.
+	PodQoSClassLabel = "koordinator.sh/qosClass"
+
+	// defaultBurstPercent means "no burst": the container stays at its base quota.
+	defaultBurstPercent = 100
+)
+
+// ParseCfsCM decodes the legacy ConfigMap-based burst policy. It returns nil
+// when the ConfigMap or its data key is absent, or when the contents don't
+// parse, rather than erroring, since that's the common case before a
+// NodeSLO or ConfigMap has been created for the node.
+func ParseCfsCM(cm *corev1.ConfigMap) *slov1alpha1.CPUBurstStrategy {
+	if cm == nil {
+		return nil
+	}
+	raw, ok := cm.Data[CfsCMDataKey]
+	if !ok {
+		return nil
+	}
+	strategy := &slov1alpha1.CPUBurstStrategy{}
+	if err := json.Unmarshal([]byte(raw), strategy); err != nil {
+		klog.Warningf("failed to parse cfs burst ConfigMap, err: %s", err)
+		return nil
+	}
+	return strategy
+}
+
+// MergeNodeStrategy prefers the NodeSLO's CPUBurstStrategy and only falls
+// back to parsing the legacy ConfigMap when no NodeSLO has been synced yet,
+// e.g. during the rollout window before the CRD is installed cluster-wide.
+func MergeNodeStrategy(nodeSLO *slov1alpha1.NodeSLO, cfsCM *corev1.ConfigMap) *slov1alpha1.CPUBurstStrategy {
+	if nodeSLO != nil && nodeSLO.Spec.CPUBurstStrategy != nil {
+		return nodeSLO.Spec.CPUBurstStrategy
+	}
+	return ParseCfsCM(cfsCM)
+}
+
+// QoSClassFromLabels maps a pod's koordinator QoS label to the
+// CPUBurstStrategy QoS classes. Pods without the label are treated as
+// best-effort (BE).
+func QoSClassFromLabels(podLabels map[string]string) slov1alpha1.QoSClass {
+	switch podLabels[PodQoSClassLabel] {
+	case string(slov1alpha1.QoSLSR):
+		return slov1alpha1.QoSLSR
+	case string(slov1alpha1.QoSLS):
+		return slov1alpha1.QoSLS
+	default:
+		return slov1alpha1.QoSBE
+	}
+}
+
+// StrategyForQoS resolves the effective strategy for a pod's QoS class,
+// falling back to the node default when no per-class override is set.
+func StrategyForQoS(base *slov1alpha1.CPUBurstStrategy, qos slov1alpha1.QoSClass) *slov1alpha1.CPUBurstStrategy {
+	if base == nil {
+		return nil
+	}
+	if override, ok := base.QoSStrategies[qos]; ok && override != nil {
+		return override
+	}
+	return base
+}
+
+// ResolveBurstPercent returns the CFS quota burst percent (e.g. 200 means
+// "burst up to 2x base quota") that applies to a container, merging in
+// priority order: the pod's own annotation, its QoS-scoped NodeSLO/CM
+// override, the node-wide default, and finally 100 (no burst) if nothing
+// else applies.
+func ResolveBurstPercent(nodeStrategy *slov1alpha1.CPUBurstStrategy, podLabels, podAnnotations map[string]string) int64 {
+	if raw, ok := podAnnotations[PodCFSQuotaBurstPercentAnnotation]; ok {
+		if percent, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return percent
+		}
+		klog.Warningf("failed to parse %v annotation %q", PodCFSQuotaBurstPercentAnnotation, raw)
+	}
+
+	strategy := StrategyForQoS(nodeStrategy, QoSClassFromLabels(podLabels))
+	if strategy != nil && strategy.CFSQuotaBurstPercent != nil {
+		return *strategy.CFSQuotaBurstPercent
+	}
+	if nodeStrategy != nil && nodeStrategy.CFSQuotaBurstPercent != nil {
+		return *nodeStrategy.CFSQuotaBurstPercent
+	}
+	return defaultBurstPercent
+}
+
+// ResolveCFSQuota applies ResolveBurstPercent's percent to a container's
+// base quota (in microseconds).
+func ResolveCFSQuota(baseQuotaUS int64, nodeStrategy *slov1alpha1.CPUBurstStrategy, podLabels, podAnnotations map[string]string) int64 {
+	if baseQuotaUS <= 0 {
+		return baseQuotaUS
+	}
+	percent := ResolveBurstPercent(nodeStrategy, podLabels, podAnnotations)
+	if percent <= defaultBurstPercent {
+		return baseQuotaUS
+	}
+	return baseQuotaUS * percent / defaultBurstPercent
+}
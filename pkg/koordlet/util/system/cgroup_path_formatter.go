@@ -0,0 +1,82 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import (
+	"fmt"
+	"strings"
+)
+
+// pathFormatter knows how to pull a bare container ID out of the basename
+// of a container's cgroup directory, which differs by container runtime
+// (docker-<id>.scope, cri-containerd-<id>.scope, ...).
+type pathFormatter struct {
+	ContainerIDParser func(basename string) (string, error)
+}
+
+// CgroupPathFormatter is the runtime-specific formatter used across this
+// package; it defaults to the containerd/CRI-O convention.
+var CgroupPathFormatter = pathFormatter{
+	ContainerIDParser: defaultContainerIDParser,
+}
+
+func defaultContainerIDParser(basename string) (string, error) {
+	name := strings.TrimSuffix(basename, ".scope")
+	if idx := strings.LastIndex(name, "-"); idx >= 0 {
+		name = name[idx+1:]
+	}
+	if len(name) == 0 {
+		return "", fmt.Errorf("failed to parse container ID from %v", basename)
+	}
+	return name, nil
+}
+
+// ParseCgroupProcs parses the newline-separated PIDs in a cgroup.procs file.
+func ParseCgroupProcs(content string) ([]uint32, error) {
+	var pids []uint32
+	for _, line := range strings.Split(strings.TrimSpace(content), "\n") {
+		if line == "" {
+			continue
+		}
+		var pid uint32
+		if _, err := fmt.Sscanf(line, "%d", &pid); err != nil {
+			return nil, fmt.Errorf("failed to parse pid from %q, err: %w", line, err)
+		}
+		pids = append(pids, pid)
+	}
+	return pids, nil
+}
+
+// DefaultCFSPeriodUS is the kernel's default cpu.cfs_period_us, in
+// microseconds. MilliCPUToQuota assumes it, and callers that need to pair a
+// quota with a period (e.g. the runtime hook's CFSPeriodUS response) should
+// use this rather than inventing their own sentinel.
+const DefaultCFSPeriodUS = 100000
+
+// MilliCPUToQuota converts a milli-CPU limit into a cpu.cfs_quota_us value
+// using DefaultCFSPeriodUS. A non-positive limit means no limit is set, so
+// the quota is -1 (unlimited).
+func MilliCPUToQuota(milliCPU int64) int64 {
+	if milliCPU <= 0 {
+		return -1
+	}
+	quota := milliCPU * DefaultCFSPeriodUS / 1000
+	if quota < 1000 {
+		quota = 1000
+	}
+	return quota
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// ResourceType names one cgroup knob or directory this package knows how to
+// locate across both hierarchy versions.
+type ResourceType int
+
+const (
+	CPUProcsName ResourceType = iota
+	CPUStatName
+	CPUCFSQuotaName
+	CPUCFSPeriodName
+	CPUMaxName
+)
+
+// CgroupResource describes where a ResourceType lives: which v1 subsystem
+// directory it is mounted under (ignored on v2) and the file name itself.
+type CgroupResource struct {
+	Subsystem string // e.g. "cpu", "cpuacct", "perf_event"; empty under v2
+	FileName  string
+}
+
+// GetCgroupResource resolves a ResourceType to its CgroupResource for the
+// currently detected hierarchy. On v2, cpu.cfs_quota_us/cpu.cfs_period_us
+// collapse into the single cpu.max file, and every subsystem directory
+// collapses into the unified root.
+func GetCgroupResource(resourceType ResourceType) (CgroupResource, error) {
+	if GetCurrentCgroupVersion() == CgroupVersionV2 {
+		switch resourceType {
+		case CPUProcsName:
+			return CgroupResource{FileName: "cgroup.procs"}, nil
+		case CPUStatName:
+			return CgroupResource{FileName: "cpu.stat"}, nil
+		case CPUCFSQuotaName, CPUCFSPeriodName, CPUMaxName:
+			return CgroupResource{FileName: "cpu.max"}, nil
+		default:
+			return CgroupResource{}, fmt.Errorf("unsupported resource type %v on cgroup v2", resourceType)
+		}
+	}
+
+	switch resourceType {
+	case CPUProcsName:
+		return CgroupResource{Subsystem: "cpu", FileName: "cgroup.procs"}, nil
+	case CPUStatName:
+		return CgroupResource{Subsystem: "cpu", FileName: "cpu.stat"}, nil
+	case CPUCFSQuotaName:
+		return CgroupResource{Subsystem: "cpu", FileName: "cpu.cfs_quota_us"}, nil
+	case CPUCFSPeriodName:
+		return CgroupResource{Subsystem: "cpu", FileName: "cpu.cfs_period_us"}, nil
+	default:
+		return CgroupResource{}, fmt.Errorf("unsupported resource type %v on cgroup v1", resourceType)
+	}
+}
+
+// GetCgroupFilePath joins the cgroup root, the resource's subsystem (a
+// no-op under v2, where Subsystem is empty) and the container's parent
+// directory into the resource's absolute file path.
+func GetCgroupFilePath(containerParentDir string, resource CgroupResource) string {
+	return filepath.Join(Conf.CgroupRootDir, resource.Subsystem, containerParentDir, resource.FileName)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestGetCgroupResource(t *testing.T) {
+	tests := []struct {
+		name         string
+		version      CgroupVersion
+		resourceType ResourceType
+		want         CgroupResource
+		wantErr      bool
+	}{
+		{
+			name:         "v1 cpu.cfs_quota_us lives under the cpu subsystem",
+			version:      CgroupVersionV1,
+			resourceType: CPUCFSQuotaName,
+			want:         CgroupResource{Subsystem: "cpu", FileName: "cpu.cfs_quota_us"},
+		},
+		{
+			name:         "v1 cpu.cfs_period_us lives under the cpu subsystem",
+			version:      CgroupVersionV1,
+			resourceType: CPUCFSPeriodName,
+			want:         CgroupResource{Subsystem: "cpu", FileName: "cpu.cfs_period_us"},
+		},
+		{
+			name:         "v1 has no single cpu.max file",
+			version:      CgroupVersionV1,
+			resourceType: CPUMaxName,
+			wantErr:      true,
+		},
+		{
+			name:         "v2 collapses quota and period into cpu.max",
+			version:      CgroupVersionV2,
+			resourceType: CPUCFSQuotaName,
+			want:         CgroupResource{FileName: "cpu.max"},
+		},
+		{
+			name:         "v2 cpu.max resource type also resolves to cpu.max",
+			version:      CgroupVersionV2,
+			resourceType: CPUMaxName,
+			want:         CgroupResource{FileName: "cpu.max"},
+		},
+		{
+			name:         "v2 cgroup.procs has no subsystem",
+			version:      CgroupVersionV2,
+			resourceType: CPUProcsName,
+			want:         CgroupResource{FileName: "cgroup.procs"},
+		},
+	}
+
+	oldVersion, oldOnce := cgroupVersion, cgroupVersionOnce
+	defer func() { cgroupVersion, cgroupVersionOnce = oldVersion, oldOnce }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cgroupVersion = tt.version
+			cgroupVersionOnce = sync.Once{}
+			cgroupVersionOnce.Do(func() {})
+
+			got, err := GetCgroupResource(tt.resourceType)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("GetCgroupResource() expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetCgroupResource() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetCgroupResource() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"k8s.io/klog/v2"
+)
+
+// CgroupVersion identifies whether the host boots with the v1 split
+// hierarchy (cpu/, cpuacct/, perf_event/, ...) or the v2 unified hierarchy.
+type CgroupVersion int
+
+const (
+	CgroupVersionV1 CgroupVersion = iota
+	CgroupVersionV2
+)
+
+// config holds the host paths that depend on the detected cgroup hierarchy.
+type config struct {
+	CgroupRootDir string
+}
+
+// Conf is the process-wide cgroup configuration, analogous to how the rest
+// of this package exposes its host-dependent settings.
+var Conf = &config{
+	CgroupRootDir: "/sys/fs/cgroup/",
+}
+
+var (
+	cgroupVersionOnce sync.Once
+	cgroupVersion     = CgroupVersionV1
+)
+
+// GetCurrentCgroupVersion auto-detects the hierarchy once per process by
+// stat'ing cgroup.controllers, which only exists under the v2 unified
+// hierarchy. The result is cached since the host's cgroup mode cannot
+// change without a reboot.
+func GetCurrentCgroupVersion() CgroupVersion {
+	cgroupVersionOnce.Do(func() {
+		unifiedControllers := filepath.Join(Conf.CgroupRootDir, "cgroup.controllers")
+		if _, err := os.Stat(unifiedControllers); err == nil {
+			cgroupVersion = CgroupVersionV2
+			klog.Infof("detected cgroup v2 unified hierarchy from %v", unifiedControllers)
+			return
+		}
+		cgroupVersion = CgroupVersionV1
+		klog.Infof("detected cgroup v1 split hierarchy, %v not found", unifiedControllers)
+	})
+	return cgroupVersion
+}
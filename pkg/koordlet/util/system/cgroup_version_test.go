@@ -0,0 +1,63 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestGetCurrentCgroupVersion(t *testing.T) {
+	tests := []struct {
+		name         string
+		writeUnified bool
+		wantVersion  CgroupVersion
+	}{
+		{
+			name:         "v1 split hierarchy has no cgroup.controllers",
+			writeUnified: false,
+			wantVersion:  CgroupVersionV1,
+		},
+		{
+			name:         "v2 unified hierarchy has cgroup.controllers",
+			writeUnified: true,
+			wantVersion:  CgroupVersionV2,
+		},
+	}
+
+	oldConf := Conf
+	defer func() { Conf = oldConf }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			if tt.writeUnified {
+				if err := os.WriteFile(filepath.Join(root, "cgroup.controllers"), []byte("cpu io memory"), 0644); err != nil {
+					t.Fatalf("failed to seed cgroup.controllers: %v", err)
+				}
+			}
+			Conf = &config{CgroupRootDir: root}
+			cgroupVersionOnce = sync.Once{}
+
+			if got := GetCurrentCgroupVersion(); got != tt.wantVersion {
+				t.Errorf("GetCurrentCgroupVersion() = %v, want %v", got, tt.wantVersion)
+			}
+		})
+	}
+}
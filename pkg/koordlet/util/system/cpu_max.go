@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CPUMaxUnlimited is the literal cpu.max writes/reads to mean "no quota".
+const CPUMaxUnlimited = "max"
+
+// CPUMax is the parsed form of a cgroup v2 cpu.max file: "<quota> <period>",
+// where quota may be the literal "max".
+type CPUMax struct {
+	QuotaUS  int64 // -1 means unlimited
+	PeriodUS int64
+}
+
+// ParseCPUMax parses a cpu.max file's contents, e.g. "100000 100000" or
+// "max 100000".
+func ParseCPUMax(content string) (CPUMax, error) {
+	fields := strings.Fields(content)
+	if len(fields) != 2 {
+		return CPUMax{}, fmt.Errorf("unexpected cpu.max content %q", content)
+	}
+
+	period, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return CPUMax{}, fmt.Errorf("failed to parse cpu.max period %q, err: %w", fields[1], err)
+	}
+
+	if fields[0] == CPUMaxUnlimited {
+		return CPUMax{QuotaUS: -1, PeriodUS: period}, nil
+	}
+	quota, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return CPUMax{}, fmt.Errorf("failed to parse cpu.max quota %q, err: %w", fields[0], err)
+	}
+	return CPUMax{QuotaUS: quota, PeriodUS: period}, nil
+}
+
+// String renders the CPUMax back into the "<quota> <period>" form cpu.max
+// expects, using "max" when QuotaUS is unlimited.
+func (c CPUMax) String() string {
+	if c.QuotaUS < 0 {
+		return fmt.Sprintf("%s %d", CPUMaxUnlimited, c.PeriodUS)
+	}
+	return fmt.Sprintf("%d %d", c.QuotaUS, c.PeriodUS)
+}
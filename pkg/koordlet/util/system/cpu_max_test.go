@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import "testing"
+
+func TestParseCPUMax(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    CPUMax
+		wantErr bool
+	}{
+		{
+			name:    "bounded quota and period",
+			content: "100000 100000",
+			want:    CPUMax{QuotaUS: 100000, PeriodUS: 100000},
+		},
+		{
+			name:    "unlimited quota",
+			content: "max 100000",
+			want:    CPUMax{QuotaUS: -1, PeriodUS: 100000},
+		},
+		{
+			name:    "trailing newline is trimmed by Fields",
+			content: "50000 100000\n",
+			want:    CPUMax{QuotaUS: 50000, PeriodUS: 100000},
+		},
+		{
+			name:    "missing field is an error",
+			content: "100000",
+			wantErr: true,
+		},
+		{
+			name:    "non-numeric quota is an error",
+			content: "abc 100000",
+			wantErr: true,
+		},
+		{
+			name:    "non-numeric period is an error",
+			content: "100000 abc",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCPUMax(tt.content)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseCPUMax(%q) expected an error, got none", tt.content)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCPUMax(%q) unexpected error: %v", tt.content, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseCPUMax(%q) = %+v, want %+v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCPUMaxString(t *testing.T) {
+	tests := []struct {
+		name string
+		in   CPUMax
+		want string
+	}{
+		{
+			name: "bounded quota",
+			in:   CPUMax{QuotaUS: 100000, PeriodUS: 100000},
+			want: "100000 100000",
+		},
+		{
+			name: "unlimited quota renders as max",
+			in:   CPUMax{QuotaUS: -1, PeriodUS: 100000},
+			want: "max 100000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.in.String(); got != tt.want {
+				t.Errorf("CPUMax.String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}